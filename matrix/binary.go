@@ -0,0 +1,159 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// binaryMagic identifies the Dense binary format; binaryFormatVersion
+// lets future revisions change the body layout without breaking
+// readers of the current one.
+const (
+	binaryMagic         = "QRM1"
+	binaryFormatVersion = 1
+)
+
+// ErrBadMagic is returned by UnmarshalBinary/ReadFrom when the input
+// does not start with the expected header.
+var ErrBadMagic = errors.New("matrix: bad magic or unsupported format version")
+
+// MarshalBinary encodes the matrix as a small header (magic, format
+// version, width, height) followed by a run-length-encoded body: each
+// run is a state byte followed by a uvarint run length. Large all-init
+// regions - which dominate a freshly allocated matrix, and the quiet
+// zone around a real QR code - collapse to a handful of bytes, well
+// under the ~12 KiB a naive one-byte-per-cell v40 (177x177) encoding
+// would take.
+func (m *Dense) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryFormatVersion)
+
+	var sizeHdr [4]byte
+	binary.BigEndian.PutUint16(sizeHdr[0:2], uint16(m.width))
+	binary.BigEndian.PutUint16(sizeHdr[2:4], uint16(m.height))
+	buf.Write(sizeHdr[:])
+
+	var varintBuf [binary.MaxVarintLen64]byte
+	writeRun := func(s State, n int) {
+		buf.WriteByte(byte(s))
+		l := binary.PutUvarint(varintBuf[:], uint64(n))
+		buf.Write(varintBuf[:l])
+	}
+
+	var (
+		run    State
+		runLen int
+		hasRun bool
+	)
+	m.Iterate(COLUMN, func(x, y int, s State) {
+		if hasRun && s == run {
+			runLen++
+			return
+		}
+		if hasRun {
+			writeRun(run, runLen)
+		}
+		run, runLen, hasRun = s, 1, true
+	})
+	if hasRun {
+		writeRun(run, runLen)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a matrix previously produced by MarshalBinary,
+// replacing the receiver's contents.
+func (m *Dense) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1+4 {
+		return ErrBadMagic
+	}
+	if string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrBadMagic
+	}
+	data = data[len(binaryMagic):]
+	if data[0] != binaryFormatVersion {
+		return ErrBadMagic
+	}
+	data = data[1:]
+
+	width := int(binary.BigEndian.Uint16(data[0:2]))
+	height := int(binary.BigEndian.Uint16(data[2:4]))
+	data = data[4:]
+
+	fresh := NewDense(width, height)
+	*m = *fresh
+
+	x, y := 0, 0
+	for len(data) > 0 {
+		s := State(data[0])
+		data = data[1:]
+
+		n, l := binary.Uvarint(data)
+		if l <= 0 {
+			return ErrBadMagic
+		}
+		data = data[l:]
+
+		for i := uint64(0); i < n; i++ {
+			if x >= m.width {
+				return ErrBadMagic
+			}
+			if err := m.Set(x, y, s); err != nil {
+				return err
+			}
+			y++
+			if y >= m.height {
+				y = 0
+				x++
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteTo streams the matrix in the MarshalBinary format.
+func (m *Dense) WriteTo(w io.Writer) (int64, error) {
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom decodes a matrix in the MarshalBinary format, replacing the
+// receiver's contents.
+func (m *Dense) ReadFrom(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(data)), err
+	}
+	if err := m.UnmarshalBinary(data); err != nil {
+		return int64(len(data)), err
+	}
+	return int64(len(data)), nil
+}
+
+// Equal reports whether a and b have the same dimensions and hold the
+// same state at every cell, regardless of backend.
+func Equal(a, b Matrix) bool {
+	aw, ah := a.Dims()
+	bw, bh := b.Dims()
+	if aw != bw || ah != bh {
+		return false
+	}
+
+	for x := 0; x < aw; x++ {
+		for y := 0; y < ah; y++ {
+			if a.At(x, y) != b.At(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
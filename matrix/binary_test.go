@@ -0,0 +1,74 @@
+package matrix
+
+import (
+	"bytes"
+	"testing"
+)
+
+// qrVersionSizes returns the symbol side length for QR versions 1-40:
+// 21x21 for version 1, growing by 4 modules per version up to 177x177
+// for version 40.
+func qrVersionSizes() []int {
+	sizes := make([]int, 40)
+	for v := 1; v <= 40; v++ {
+		sizes[v-1] = 21 + 4*(v-1)
+	}
+	return sizes
+}
+
+func TestMarshalUnmarshalBinaryRoundTrip(t *testing.T) {
+	sizes := qrVersionSizes()
+
+	for _, size := range sizes {
+		m := NewDense(size, size)
+		for x := 0; x < size; x++ {
+			for y := 0; y < size; y++ {
+				if (x+y)%7 == 0 {
+					_ = m.Set(x, y, StateFinder)
+				} else if (x*y)%5 == 0 {
+					_ = m.Set(x, y, StateTrue)
+				}
+			}
+		}
+
+		data, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("size %d: MarshalBinary: %v", size, err)
+		}
+
+		got := NewDense(1, 1)
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("size %d: UnmarshalBinary: %v", size, err)
+		}
+
+		if !Equal(m, got) {
+			t.Fatalf("size %d: round trip did not preserve matrix contents", size)
+		}
+	}
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	m := NewDense(21, 21)
+	_ = m.Set(3, 3, StateVersion)
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	got := NewDense(1, 1)
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+
+	if !Equal(m, got) {
+		t.Fatal("ReadFrom did not reproduce the matrix written by WriteTo")
+	}
+}
+
+func TestUnmarshalBinaryBadMagic(t *testing.T) {
+	m := NewDense(1, 1)
+	if err := m.UnmarshalBinary([]byte("nope")); err != ErrBadMagic {
+		t.Fatalf("got err %v, want ErrBadMagic", err)
+	}
+}
@@ -0,0 +1,104 @@
+package matrix
+
+import (
+	"github.com/BuckeyeCoder/go-qrcode/matrix/gf256"
+)
+
+// ErrTooManyErrors mirrors gf256.ErrTooManyErrors for callers that only
+// import the matrix package.
+var ErrTooManyErrors = gf256.ErrTooManyErrors
+
+// ErrTooManyErasures mirrors gf256.ErrTooManyErasures for callers that
+// only import the matrix package.
+var ErrTooManyErasures = gf256.ErrTooManyErasures
+
+// extractCodewords walks a bit matrix via the standard QR zig-zag
+// traversal (two-module-wide columns, bottom-to-top then top-to-bottom,
+// skipping the vertical timing column) and packs StateTrue/StateFalse
+// bits into bytes, most-significant bit first. Reserved modules
+// (StateFinder/StateFormat/StateVersion/StateInit) are skipped, exactly
+// as the encoder skips them when laying codewords down.
+func extractCodewords(m Matrix) []byte {
+	w, h := m.Dims()
+
+	var bits []bool
+	upward := true
+	for right := w - 1; right > 0; right -= 2 {
+		if right == 6 { // vertical timing pattern column
+			right--
+		}
+		for i := 0; i < h; i++ {
+			y := i
+			if upward {
+				y = h - 1 - i
+			}
+			for _, x := range [2]int{right, right - 1} {
+				switch m.At(x, y) {
+				case StateTrue:
+					bits = append(bits, true)
+				case StateFalse:
+					bits = append(bits, false)
+				}
+			}
+		}
+		upward = !upward
+	}
+
+	out := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			out[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+	return out
+}
+
+// Decode reads the codewords out of a masked, damaged QR bit matrix and
+// repairs them using Reed-Solomon over GF(256). mask is applied to
+// every data/error correction module before codewords are extracted -
+// pass the mask function the encoder used to produce m. dataShards is
+// the number of data codewords k; the remaining codewords in the
+// extracted stream are treated as the n-k parity shards.
+//
+// If erasures is non-empty, its entries are 0-based codeword indices
+// the caller already knows are unreliable (e.g. a known-damaged scan
+// region). Decode computes the n-k syndromes of the received
+// codewords, runs Berlekamp-Massey (seeded with the known erasures) to
+// find the combined error-and-erasure locator polynomial, locates its
+// roots by Chien search, and corrects them via Forney's algorithm -
+// recovering up to n-k erasures, or floor((n-k)/2) errors with no
+// erasures given, the standard RS correction bound.
+func Decode(m Matrix, mask func(x, y int) bool, dataShards int, erasures ...int) ([]byte, error) {
+	unmasked := m.Copy()
+	w, h := m.Dims()
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			s := m.At(x, y)
+			if s != StateTrue && s != StateFalse {
+				continue
+			}
+			if mask(x, y) {
+				_ = unmasked.Set(x, y, XOR(s, StateTrue))
+			}
+		}
+	}
+
+	codewords := extractCodewords(unmasked)
+	n := len(codewords)
+	if dataShards <= 0 || dataShards > n {
+		return nil, ErrDimMismatch
+	}
+	parityShards := n - dataShards
+
+	for _, e := range erasures {
+		if e < 0 || e >= n {
+			return nil, ErrDimMismatch
+		}
+	}
+
+	return gf256.Decode(codewords, parityShards, erasures)
+}
+
+// ErrDimMismatch mirrors gf256.ErrDimMismatch for callers that only
+// import the matrix package.
+var ErrDimMismatch = gf256.ErrDimMismatch
@@ -0,0 +1,183 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/BuckeyeCoder/go-qrcode/matrix/gf256"
+)
+
+// noMask leaves every module as-is, i.e. simulates mask pattern 0 being
+// already undone before Decode sees the matrix.
+func noMask(x, y int) bool { return false }
+
+// encode builds a properly Reed-Solomon encoded codeword stream for
+// data using the same generator-polynomial construction Decode
+// expects, so tests can corrupt or erase individual codewords and
+// check that Decode actually repairs them rather than just echoing
+// back whatever bytes it read.
+func encode(t *testing.T, data []byte, parityShards int) []byte {
+	t.Helper()
+	return gf256.Encode(data, parityShards)
+}
+
+func TestDecodeRoundTripNoDamage(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x55}
+	codewords := encode(t, data, 4)
+
+	m := NewDense(21, 21)
+	writeCodewords(t, m, codewords)
+
+	got, err := Decode(m, noMask, len(data))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode = %v, want %v", got, data)
+	}
+}
+
+func TestDecodeRepairsCorruptedCodewords(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x55}
+	const parityShards = 4 // tolerates floor(4/2) = 2 corrupted codewords
+	codewords := encode(t, data, parityShards)
+
+	damaged := append([]byte(nil), codewords...)
+	damaged[1] ^= 0xFF
+	damaged[5] ^= 0x0F
+
+	m := NewDense(21, 21)
+	writeCodewords(t, m, damaged)
+
+	got, err := Decode(m, noMask, len(data))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode did not repair corrupted codewords: got %v, want %v", got, data)
+	}
+}
+
+func TestDecodeRepairsRealisticQRVersion1MBlock(t *testing.T) {
+	// A version 1-M block: 16 data codewords, 10 parity codewords
+	// (n=26), correctable up to floor(10/2) = 5 errors.
+	data := make([]byte, 16)
+	for i := range data {
+		data[i] = byte(i * 17)
+	}
+	const parityShards = 10
+	codewords := encode(t, data, parityShards)
+
+	damaged := append([]byte(nil), codewords...)
+	damaged[3] ^= 0xFF
+	damaged[20] ^= 0x55
+
+	m := NewDense(21, 21)
+	writeCodewords(t, m, damaged)
+
+	got, err := Decode(m, noMask, len(data))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode did not repair corrupted codewords: got %v, want %v", got, data)
+	}
+}
+
+func TestDecodeFailsBeyondCorrectionCapacity(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x55}
+	const parityShards = 4 // tolerates only 2 corrupted codewords
+	codewords := encode(t, data, parityShards)
+
+	damaged := append([]byte(nil), codewords...)
+	damaged[0] ^= 0xFF
+	damaged[1] ^= 0xFF
+	damaged[2] ^= 0xFF
+
+	m := NewDense(21, 21)
+	writeCodewords(t, m, damaged)
+
+	if _, err := Decode(m, noMask, len(data)); err != ErrTooManyErrors {
+		t.Fatalf("Decode with 3 corrupted codewords (max 2 correctable) = %v, want ErrTooManyErrors", err)
+	}
+}
+
+func TestDecodeRecoversKnownErasures(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	const parityShards = 3
+	codewords := encode(t, data, parityShards)
+
+	erased := append([]byte(nil), codewords...)
+	erasures := []int{0, 4, 5}
+	for _, e := range erasures {
+		erased[e] = 0 // simulate an unreadable/blank module
+	}
+
+	m := NewDense(21, 21)
+	writeCodewords(t, m, erased)
+
+	got, err := Decode(m, noMask, len(data), erasures...)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode did not recover erased codewords: got %v, want %v", got, data)
+	}
+}
+
+func TestDecodeTooManyErasures(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	const parityShards = 3
+	codewords := encode(t, data, parityShards)
+
+	m := NewDense(21, 21)
+	writeCodewords(t, m, codewords)
+
+	// parityShards erasures is the maximum recoverable; one more fails.
+	erasures := []int{0, 1, 2, 3}
+	if _, err := Decode(m, noMask, len(data), erasures...); err != ErrTooManyErasures {
+		t.Fatalf("Decode with %d erasures (max %d) = %v, want ErrTooManyErasures", len(erasures), parityShards, err)
+	}
+}
+
+// writeCodewords lays bits down using the same zig-zag order
+// extractCodewords reads them back in, so the round trip above only
+// tests Decode's own logic rather than a full encoder.
+func writeCodewords(t *testing.T, m Matrix, codewords []byte) {
+	t.Helper()
+	w, h := m.Dims()
+
+	var bits []bool
+	for _, c := range codewords {
+		for i := 7; i >= 0; i-- {
+			bits = append(bits, (c>>uint(i))&1 == 1)
+		}
+	}
+
+	idx := 0
+	upward := true
+	for right := w - 1; right > 0 && idx < len(bits); right -= 2 {
+		if right == 6 {
+			right--
+		}
+		for i := 0; i < h && idx < len(bits); i++ {
+			y := i
+			if upward {
+				y = h - 1 - i
+			}
+			for _, x := range [2]int{right, right - 1} {
+				if idx >= len(bits) {
+					break
+				}
+				s := StateFalse
+				if bits[idx] {
+					s = StateTrue
+				}
+				if err := m.Set(x, y, s); err != nil {
+					t.Fatalf("Set(%d,%d): %v", x, y, err)
+				}
+				idx++
+			}
+		}
+		upward = !upward
+	}
+}
@@ -0,0 +1,86 @@
+// Package gf256 implements arithmetic over GF(2^8) using the QR
+// standard's primitive polynomial (0x11D), plus the Cauchy-matrix
+// Reed-Solomon construction used to erasure-decode damaged QR codes:
+// build an (n+k)xk encoding matrix whose top kxk block is the identity
+// and whose bottom (n-k)xk block is Cauchy (1/(i^j)), so that any k
+// surviving rows form an invertible kxk submatrix. This is the same
+// construction Backblaze's reedsolomon library uses.
+package gf256
+
+// primitivePoly is the QR standard's GF(2^8) reducing polynomial,
+// x^8 + x^4 + x^3 + x^2 + 1.
+const primitivePoly = 0x11D
+
+var (
+	expTable [512]byte
+	logTable [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x >= 0x100 {
+			x ^= primitivePoly
+		}
+	}
+	// Duplicate the table so Mul/Div can index exp[log(a)+log(b)]
+	// without reducing mod 255 on every call.
+	for i := 255; i < 512; i++ {
+		expTable[i] = expTable[i-255]
+	}
+}
+
+// Add is addition in GF(2^8), which is XOR. It doubles as subtraction.
+func Add(a, b byte) byte {
+	return a ^ b
+}
+
+// Mul multiplies two GF(2^8) elements.
+func Mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+// Div divides a by b in GF(2^8). b must be non-zero.
+func Div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	if b == 0 {
+		panic("gf256: division by zero")
+	}
+	diff := int(logTable[a]) - int(logTable[b])
+	if diff < 0 {
+		diff += 255
+	}
+	return expTable[diff]
+}
+
+// Pow raises a to the n-th power in GF(2^8).
+func Pow(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	e := (int(logTable[a]) * n) % 255
+	if e < 0 {
+		e += 255
+	}
+	return expTable[e]
+}
+
+// Inverse returns the multiplicative inverse of a in GF(2^8). a must be
+// non-zero.
+func Inverse(a byte) byte {
+	if a == 0 {
+		panic("gf256: zero has no inverse")
+	}
+	return expTable[255-int(logTable[a])]
+}
@@ -0,0 +1,91 @@
+package gf256
+
+import "testing"
+
+func TestMulDivInverse(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		inv := Inverse(byte(a))
+		if got := Mul(byte(a), inv); got != 1 {
+			t.Fatalf("Mul(%d, Inverse(%d)=%d) = %d, want 1", a, a, inv, got)
+		}
+		if got := Div(byte(a), byte(a)); got != 1 {
+			t.Fatalf("Div(%d, %d) = %d, want 1", a, a, got)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	if got := Pow(3, 0); got != 1 {
+		t.Fatalf("Pow(3, 0) = %d, want 1", got)
+	}
+	a := byte(7)
+	if got := Pow(a, 2); got != Mul(a, a) {
+		t.Fatalf("Pow(7, 2) = %d, want %d", got, Mul(a, a))
+	}
+}
+
+func TestCauchyEncodingMatrixInvertibleSubsets(t *testing.T) {
+	const dataShards, parityShards = 4, 3
+	enc, err := NewCauchyEncodingMatrix(dataShards, parityShards)
+	if err != nil {
+		t.Fatalf("NewCauchyEncodingMatrix: %v", err)
+	}
+
+	// Any `dataShards` rows of the encoding matrix should be invertible,
+	// which is the whole point of the Cauchy construction.
+	subsets := [][]int{
+		{0, 1, 2, 3},
+		{0, 1, 2, 4},
+		{3, 4, 5, 6},
+		{1, 3, 5, 6},
+	}
+	for _, rows := range subsets {
+		sub, err := enc.SubRows(rows)
+		if err != nil {
+			t.Fatalf("SubRows(%v): %v", rows, err)
+		}
+		if _, err := sub.Invert(); err != nil {
+			t.Fatalf("subset %v should be invertible: %v", rows, err)
+		}
+	}
+}
+
+func TestInvertRoundTrip(t *testing.T) {
+	const dataShards, parityShards = 3, 2
+	enc, err := NewCauchyEncodingMatrix(dataShards, parityShards)
+	if err != nil {
+		t.Fatalf("NewCauchyEncodingMatrix: %v", err)
+	}
+
+	data := []byte{1, 2, 3}
+	shards, err := enc.MulVector(data)
+	if err != nil {
+		t.Fatalf("MulVector: %v", err)
+	}
+
+	// Drop shard 1 (an erasure) and recover from 3 of the remaining 4.
+	rows := []int{0, 2, 3}
+	sub, err := enc.SubRows(rows)
+	if err != nil {
+		t.Fatalf("SubRows: %v", err)
+	}
+	received := make([]byte, len(rows))
+	for i, r := range rows {
+		received[i] = shards[r]
+	}
+
+	inv, err := sub.Invert()
+	if err != nil {
+		t.Fatalf("Invert: %v", err)
+	}
+	got, err := inv.MulVector(received)
+	if err != nil {
+		t.Fatalf("MulVector: %v", err)
+	}
+
+	for i := range data {
+		if got[i] != data[i] {
+			t.Fatalf("recovered data = %v, want %v", got, data)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package gf256
+
+import "errors"
+
+var (
+	// ErrSingular is returned when a matrix has no inverse.
+	ErrSingular = errors.New("gf256: matrix is singular")
+
+	// ErrDimMismatch is returned when an operation receives matrices or
+	// vectors whose dimensions are incompatible.
+	ErrDimMismatch = errors.New("gf256: dimension mismatch")
+
+	// ErrNotEnoughShards is returned when fewer than k rows are
+	// available to decode.
+	ErrNotEnoughShards = errors.New("gf256: not enough surviving rows to decode")
+
+	// ErrTooManyErrors is returned by Decode when the number of damaged
+	// codeword positions exceeds the code's correction capacity.
+	ErrTooManyErrors = errors.New("gf256: too many errors to repair")
+
+	// ErrTooManyErasures is returned by Decode when more erasure
+	// positions are supplied than the code has parity bytes.
+	ErrTooManyErasures = errors.New("gf256: too many erasures to repair")
+)
+
+// Matrix is a dense matrix of GF(2^8) elements, stored row-major.
+type Matrix [][]byte
+
+// NewMatrix allocates a rows x cols zero matrix.
+func NewMatrix(rows, cols int) Matrix {
+	m := make(Matrix, rows)
+	for r := range m {
+		m[r] = make([]byte, cols)
+	}
+	return m
+}
+
+// Identity returns the n x n identity matrix.
+func Identity(n int) Matrix {
+	m := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m[i][i] = 1
+	}
+	return m
+}
+
+// NewCauchyEncodingMatrix builds the (n+k) x k Reed-Solomon encoding
+// matrix used by QR-style erasure coding: rows 0..k-1 are the identity
+// (the original data shards pass through unchanged), rows k..n+k-1 are
+// Cauchy parity rows where row i, column j holds 1/(i^j). Any k rows of
+// the result are guaranteed to be linearly independent, so any k
+// surviving shards - data or parity - are enough to reconstruct the
+// rest.
+func NewCauchyEncodingMatrix(dataShards, parityShards int) (Matrix, error) {
+	if dataShards <= 0 || parityShards < 0 {
+		return nil, ErrDimMismatch
+	}
+	n := dataShards + parityShards
+	m := NewMatrix(n, dataShards)
+
+	for i := 0; i < dataShards; i++ {
+		m[i][i] = 1
+	}
+
+	for i := dataShards; i < n; i++ {
+		for j := 0; j < dataShards; j++ {
+			denom := byte(i) ^ byte(j)
+			if denom == 0 {
+				return nil, ErrSingular
+			}
+			m[i][j] = Div(1, denom)
+		}
+	}
+
+	return m, nil
+}
+
+// SubRows returns the matrix made of the given row indices, in order.
+func (m Matrix) SubRows(rows []int) (Matrix, error) {
+	out := make(Matrix, len(rows))
+	for i, r := range rows {
+		if r < 0 || r >= len(m) {
+			return nil, ErrDimMismatch
+		}
+		out[i] = m[r]
+	}
+	return out, nil
+}
+
+// Invert computes the inverse of a square matrix via Gauss-Jordan
+// elimination over GF(2^8), swapping in a lower pivot row whenever the
+// current pivot is zero. Returns ErrSingular if no invertible pivot
+// exists in some column - i.e. the submatrix chosen for decoding was
+// not actually independent.
+func (m Matrix) Invert() (Matrix, error) {
+	n := len(m)
+	for _, row := range m {
+		if len(row) != n {
+			return nil, ErrDimMismatch
+		}
+	}
+
+	work := make(Matrix, n)
+	for i := range m {
+		work[i] = append([]byte(nil), m[i]...)
+	}
+	inv := Identity(n)
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for r := col; r < n; r++ {
+			if work[r][col] != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot == -1 {
+			return nil, ErrSingular
+		}
+		if pivot != col {
+			work[col], work[pivot] = work[pivot], work[col]
+			inv[col], inv[pivot] = inv[pivot], inv[col]
+		}
+
+		scale := Inverse(work[col][col])
+		for j := 0; j < n; j++ {
+			work[col][j] = Mul(work[col][j], scale)
+			inv[col][j] = Mul(inv[col][j], scale)
+		}
+
+		for r := 0; r < n; r++ {
+			if r == col || work[r][col] == 0 {
+				continue
+			}
+			factor := work[r][col]
+			for j := 0; j < n; j++ {
+				work[r][j] = Add(work[r][j], Mul(factor, work[col][j]))
+				inv[r][j] = Add(inv[r][j], Mul(factor, inv[col][j]))
+			}
+		}
+	}
+
+	return inv, nil
+}
+
+// MulVector multiplies this matrix by a column vector, returning an
+// len(m)-element result.
+func (m Matrix) MulVector(v []byte) ([]byte, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	if len(m[0]) != len(v) {
+		return nil, ErrDimMismatch
+	}
+
+	out := make([]byte, len(m))
+	for r, row := range m {
+		var sum byte
+		for c, a := range row {
+			sum = Add(sum, Mul(a, v[c]))
+		}
+		out[r] = sum
+	}
+	return out, nil
+}
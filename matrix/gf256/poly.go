@@ -0,0 +1,91 @@
+package gf256
+
+// Poly is a polynomial over GF(2^8), stored highest-degree coefficient
+// first: Poly{c0, c1, ..., cn} represents c0*x^n + c1*x^(n-1) + ... + cn.
+type Poly []byte
+
+func polyScale(p Poly, x byte) Poly {
+	out := make(Poly, len(p))
+	for i, c := range p {
+		out[i] = Mul(c, x)
+	}
+	return out
+}
+
+// polyAdd adds (XORs) two polynomials, right-aligning the shorter one
+// so coefficients of equal degree line up.
+func polyAdd(p, q Poly) Poly {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	out := make(Poly, n)
+	copy(out[n-len(p):], p)
+	for i, c := range q {
+		out[n-len(q)+i] ^= c
+	}
+	return out
+}
+
+func polyMul(p, q Poly) Poly {
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	out := make(Poly, len(p)+len(q)-1)
+	for i, cp := range p {
+		if cp == 0 {
+			continue
+		}
+		for j, cq := range q {
+			out[i+j] = Add(out[i+j], Mul(cp, cq))
+		}
+	}
+	return out
+}
+
+// polyEval evaluates p(x) via Horner's method.
+func polyEval(p Poly, x byte) byte {
+	if len(p) == 0 {
+		return 0
+	}
+	y := p[0]
+	for i := 1; i < len(p); i++ {
+		y = Add(Mul(y, x), p[i])
+	}
+	return y
+}
+
+// polyDivMod divides dividend by the monic polynomial divisor
+// (divisor[0] == 1), returning the quotient and remainder. Used for
+// systematic RS encoding, where divisor is the generator polynomial.
+func polyDivMod(dividend, divisor Poly) (quotient, remainder Poly) {
+	out := append(Poly(nil), dividend...)
+	for i := 0; i <= len(out)-len(divisor); i++ {
+		coef := out[i]
+		if coef == 0 {
+			continue
+		}
+		for j := 1; j < len(divisor); j++ {
+			if divisor[j] != 0 {
+				out[i+j] = Add(out[i+j], Mul(divisor[j], coef))
+			}
+		}
+	}
+	sep := len(dividend) - (len(divisor) - 1)
+	if sep < 0 {
+		sep = 0
+	}
+	return out[:sep], out[sep:]
+}
+
+// reverse returns p with its coefficients in the opposite order, used
+// to convert between the high-degree-first Poly convention and the
+// low-degree-first (index == power of x) convention syndromes are
+// naturally expressed in.
+func reverse(p Poly) Poly {
+	out := make(Poly, len(p))
+	for i, c := range p {
+		out[len(p)-1-i] = c
+	}
+	return out
+}
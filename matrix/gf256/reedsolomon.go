@@ -0,0 +1,268 @@
+package gf256
+
+// This file implements the classical syndrome-based Reed-Solomon
+// decoder: compute syndromes, run Berlekamp-Massey to find the
+// error(+erasure) locator polynomial, locate the error positions by
+// Chien search (evaluating the locator at every field element), and
+// correct the located positions via the Forney algorithm. This is the
+// standard approach real-world RS codes (including the one QR codes
+// use) are decoded with; unlike brute-forcing row subsets of an
+// encoding matrix, its cost is independent of how many codewords n has.
+//
+// generatorRoot is the primitive element QR's RS code evaluates the
+// generator polynomial's roots at: α^0, α^1, ..., α^(nsym-1).
+const generatorRoot = 2
+
+// GeneratorPoly returns the RS generator polynomial with nsym
+// consecutive roots α^0..α^(nsym-1).
+func GeneratorPoly(nsym int) Poly {
+	g := Poly{1}
+	for i := 0; i < nsym; i++ {
+		g = polyMul(g, Poly{1, Pow(generatorRoot, i)})
+	}
+	return g
+}
+
+// Encode appends nsym Reed-Solomon parity bytes to data, returning the
+// full systematic codeword (data unchanged, followed by parity).
+func Encode(data []byte, nsym int) []byte {
+	gen := GeneratorPoly(nsym)
+
+	msg := make(Poly, len(data)+nsym)
+	copy(msg, data)
+	_, remainder := polyDivMod(msg, gen)
+
+	out := make([]byte, len(data)+nsym)
+	copy(out, data)
+	copy(out[len(data):], remainder)
+	return out
+}
+
+// Syndromes evaluates codeword at the generator's nsym roots,
+// S_i = codeword(α^i) for i in [0, nsym). All syndromes are zero if
+// and only if codeword is a valid, undamaged codeword. The result is
+// indexed by power of α (S_0 first), not by polynomial degree.
+func Syndromes(codeword []byte, nsym int) []byte {
+	synd := make([]byte, nsym)
+	p := Poly(codeword)
+	for i := 0; i < nsym; i++ {
+		synd[i] = polyEval(p, Pow(generatorRoot, i))
+	}
+	return synd
+}
+
+func syndromesAllZero(synd []byte) bool {
+	for _, s := range synd {
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// errataLocator builds the polynomial whose roots are α^-coefPos for
+// each position in coefPos, i.e. prod(1 + α^coefPos[i] * x).
+func errataLocator(coefPos []int) Poly {
+	loc := Poly{1}
+	for _, cp := range coefPos {
+		loc = polyMul(loc, Poly{Pow(generatorRoot, cp), 1})
+	}
+	return loc
+}
+
+// findErrorLocator runs Berlekamp-Massey, seeded with the known
+// erasure locator (eraseLoc, eraseCount), to find the combined
+// error+erasure locator polynomial. Its roots - found later by Chien
+// search - give every damaged codeword position, known erasures and
+// newly discovered errors alike.
+func findErrorLocator(synd []byte, nsym int, eraseLoc Poly, eraseCount int) (Poly, error) {
+	errLoc := append(Poly(nil), eraseLoc...)
+	oldLoc := append(Poly(nil), eraseLoc...)
+
+	syndShift := 0
+	if len(synd) > nsym {
+		syndShift = len(synd) - nsym
+	}
+
+	for i := 0; i < nsym-eraseCount; i++ {
+		k := eraseCount + i + syndShift
+
+		delta := synd[k]
+		for j := 1; j < len(errLoc); j++ {
+			delta = Add(delta, Mul(errLoc[len(errLoc)-1-j], synd[k-j]))
+		}
+
+		oldLoc = append(oldLoc, 0)
+		if delta != 0 {
+			if len(oldLoc) > len(errLoc) {
+				newLoc := polyScale(oldLoc, delta)
+				oldLoc = polyScale(errLoc, Inverse(delta))
+				errLoc = newLoc
+			}
+			errLoc = polyAdd(errLoc, polyScale(oldLoc, delta))
+		}
+	}
+
+	for len(errLoc) > 0 && errLoc[0] == 0 {
+		errLoc = errLoc[1:]
+	}
+
+	errs := len(errLoc) - 1
+	if (errs-eraseCount)*2+eraseCount > nsym {
+		return nil, ErrTooManyErrors
+	}
+	return errLoc, nil
+}
+
+// findErrorPositions locates the roots of errLoc by Chien search. A
+// damaged codeword byte at index p corresponds to error locator
+// α^(n-1-p), so errLoc's roots sit at α^(p+1-n); try that value for
+// every candidate position p in [0, n) rather than every power of α,
+// since a codeword this short can't place an error outside it. Returns
+// nil if the roots found don't match the locator's degree, meaning
+// there are more errors than the code can correct.
+func findErrorPositions(errLoc Poly, n int) []int {
+	want := len(errLoc) - 1
+	if want == 0 {
+		return nil
+	}
+
+	var positions []int
+	for p := 0; p < n; p++ {
+		root := Pow(generatorRoot, mod255(p+1-n))
+		if polyEval(errLoc, root) == 0 {
+			positions = append(positions, p)
+		}
+	}
+	if len(positions) != want {
+		return nil
+	}
+	return positions
+}
+
+// findErrorEvaluator computes the error evaluator polynomial
+// Omega(x) = (S(x) * errLoc(x)) mod x^nsym, returned low-degree first
+// (index == power of x), matching how Syndromes is indexed.
+func findErrorEvaluator(synd []byte, errLoc Poly, nsym int) Poly {
+	prod := polyMul(reverse(Poly(synd)), errLoc)
+	keep := nsym
+	var remainder Poly
+	if len(prod) > keep {
+		remainder = prod[len(prod)-keep:]
+	} else {
+		remainder = prod
+	}
+	return reverse(remainder)
+}
+
+// dedupePositions drops repeated indices, preserving first-seen order,
+// so a caller passing the same erasure position more than once doesn't
+// inflate the errata locator's degree with repeated roots.
+func dedupePositions(positions []int) []int {
+	seen := make(map[int]bool, len(positions))
+	out := make([]int, 0, len(positions))
+	for _, p := range positions {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func mod255(x int) int {
+	m := x % 255
+	if m < 0 {
+		m += 255
+	}
+	return m
+}
+
+// correctErrata applies the Forney algorithm to repair codeword at
+// every position in errPos, given the already-computed syndromes.
+func correctErrata(codeword []byte, synd []byte, errPos []int, nsym int) ([]byte, error) {
+	n := len(codeword)
+	coefPos := make([]int, len(errPos))
+	for i, p := range errPos {
+		coefPos[i] = n - 1 - p
+	}
+
+	errLoc := errataLocator(coefPos)
+	errEval := findErrorEvaluator(synd, errLoc, nsym)
+	errEvalHighFirst := reverse(errEval)
+
+	// y[i] is the root of errLoc for error i, y[i] = α^-coefPos[i]; the
+	// locator value itself (as it appears in the syndrome equations) is
+	// its inverse, α^coefPos[i].
+	y := make([]byte, len(coefPos))
+	for i, cp := range coefPos {
+		y[i] = Pow(generatorRoot, mod255(-cp))
+	}
+
+	e := make([]byte, n)
+	for i, yi := range y {
+		prime := byte(1)
+		for j, yj := range y {
+			if j == i {
+				continue
+			}
+			prime = Mul(prime, Add(1, Mul(Inverse(yj), yi)))
+		}
+		if prime == 0 {
+			return nil, ErrTooManyErrors
+		}
+
+		e[errPos[i]] = Div(polyEval(errEvalHighFirst, yi), prime)
+	}
+
+	corrected := make([]byte, n)
+	for i := range codeword {
+		corrected[i] = Add(codeword[i], e[i])
+	}
+	return corrected, nil
+}
+
+// Decode repairs a Reed-Solomon codeword of n = len(codeword) bytes,
+// the last nsym of which are parity, and returns the original
+// len(codeword)-nsym data bytes. erasurePositions are 0-based codeword
+// indices already known to be unreliable; Decode can repair up to
+// 2*errors+len(erasurePositions) <= nsym combined errors and erasures
+// (so up to nsym erasures alone, or floor(nsym/2) errors alone).
+func Decode(codeword []byte, nsym int, erasurePositions []int) ([]byte, error) {
+	erasurePositions = dedupePositions(erasurePositions)
+	if len(erasurePositions) > nsym {
+		return nil, ErrTooManyErasures
+	}
+
+	synd := Syndromes(codeword, nsym)
+	if syndromesAllZero(synd) {
+		return append([]byte(nil), codeword[:len(codeword)-nsym]...), nil
+	}
+
+	coefPos := make([]int, len(erasurePositions))
+	for i, p := range erasurePositions {
+		coefPos[i] = len(codeword) - 1 - p
+	}
+	eraseLoc := errataLocator(coefPos)
+
+	errLoc, err := findErrorLocator(synd, nsym, eraseLoc, len(erasurePositions))
+	if err != nil {
+		return nil, err
+	}
+
+	errPos := findErrorPositions(errLoc, len(codeword))
+	if errPos == nil {
+		return nil, ErrTooManyErrors
+	}
+
+	corrected, err := correctErrata(codeword, synd, errPos, nsym)
+	if err != nil {
+		return nil, err
+	}
+
+	if !syndromesAllZero(Syndromes(corrected, nsym)) {
+		return nil, ErrTooManyErrors
+	}
+
+	return corrected[:len(corrected)-nsym], nil
+}
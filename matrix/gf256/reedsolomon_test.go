@@ -0,0 +1,97 @@
+package gf256
+
+import "testing"
+
+func TestEncodeDecodeRoundTripNoDamage(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x55}
+	codeword := Encode(data, 6)
+
+	got, err := Decode(codeword, 6, nil)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode = %v, want %v", got, data)
+	}
+}
+
+func TestDecodeCorrectsErrorsUpToCapacity(t *testing.T) {
+	// 16 data codewords, 10 parity (n=26) is QR version 1-M's block
+	// shape, correctable up to floor(10/2) = 5 errors.
+	data := make([]byte, 16)
+	for i := range data {
+		data[i] = byte(i * 17)
+	}
+	codeword := Encode(data, 10)
+
+	damaged := append([]byte(nil), codeword...)
+	for _, i := range []int{1, 5, 9, 15, 24} {
+		damaged[i] ^= 0xFF
+	}
+
+	got, err := Decode(damaged, 10, nil)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode = %v, want %v", got, data)
+	}
+}
+
+func TestDecodeFailsBeyondCapacity(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x55}
+	codeword := Encode(data, 4) // tolerates floor(4/2) = 2 errors
+
+	damaged := append([]byte(nil), codeword...)
+	damaged[0] ^= 0xFF
+	damaged[1] ^= 0xFF
+	damaged[2] ^= 0xFF
+
+	if _, err := Decode(damaged, 4, nil); err == nil {
+		t.Fatal("expected an error with 3 damaged bytes (max 2 correctable)")
+	}
+}
+
+func TestDecodeRecoversErasures(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	codeword := Encode(data, 3)
+
+	erased := append([]byte(nil), codeword...)
+	erasures := []int{0, 4, 5}
+	for _, e := range erasures {
+		erased[e] = 0
+	}
+
+	got, err := Decode(erased, 3, erasures)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode = %v, want %v", got, data)
+	}
+}
+
+func TestDecodeDedupesRepeatedErasurePositions(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	codeword := Encode(data, 3)
+
+	erased := append([]byte(nil), codeword...)
+	erased[0] = 0
+
+	got, err := Decode(erased, 3, []int{0, 0, 0})
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("Decode = %v, want %v", got, data)
+	}
+}
+
+func TestDecodeTooManyErasures(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03}
+	codeword := Encode(data, 3)
+
+	if _, err := Decode(codeword, 3, []int{0, 1, 2, 3}); err != ErrTooManyErasures {
+		t.Fatalf("Decode with 4 erasures (max 3) = %v, want ErrTooManyErasures", err)
+	}
+}
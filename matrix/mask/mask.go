@@ -0,0 +1,54 @@
+// Package mask scores QR mask-pattern candidates. Evaluating all eight
+// mask patterns against the four penalty rules is embarrassingly
+// parallel - each cell's contribution to a rule's score is independent
+// of every other cell - which is what ParallelPenalty takes advantage
+// of.
+package mask
+
+import "github.com/BuckeyeCoder/go-qrcode/matrix"
+
+// PenaltyRule scores a single cell's contribution to a mask penalty
+// rule, given its neighbourhood via m. Rules that need neighbouring
+// cells (e.g. QR rule 1's run-length check) read them back out of m
+// with m.At; ParallelPenalty only guarantees that writes are confined
+// to the caller's own per-stripe accumulator, not that m itself changes
+// during a call.
+type PenaltyRule func(m matrix.Matrix, x, y int, s matrix.State) int
+
+// parallelIterable is implemented by matrix backends that support
+// striped concurrent iteration, currently matrix.Dense.
+type parallelIterable interface {
+	IterateParallel(dir matrix.ScanDirection, stripes int, f matrix.ParallelIterateFunc)
+}
+
+// ParallelPenalty scores every cell of m against rule, splitting the
+// work across stripes goroutines when m's backend supports
+// matrix.IterateParallel, and falling back to a serial matrix.Iterate
+// otherwise. Each stripe accumulates into its own slot of a
+// stripes-length slice; the slots are summed once all stripes finish,
+// so the concurrent stripes never contend on a shared counter.
+func ParallelPenalty(m matrix.Matrix, dir matrix.ScanDirection, stripes int, rule PenaltyRule) int {
+	if stripes < 1 {
+		stripes = 1
+	}
+
+	pi, ok := m.(parallelIterable)
+	if !ok {
+		total := 0
+		m.Iterate(dir, func(x, y int, s matrix.State) {
+			total += rule(m, x, y, s)
+		})
+		return total
+	}
+
+	accs := make([]int, stripes)
+	pi.IterateParallel(dir, stripes, func(stripe, x, y int, s matrix.State) {
+		accs[stripe] += rule(m, x, y, s)
+	})
+
+	total := 0
+	for _, a := range accs {
+		total += a
+	}
+	return total
+}
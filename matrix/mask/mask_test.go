@@ -0,0 +1,52 @@
+package mask
+
+import (
+	"testing"
+
+	"github.com/BuckeyeCoder/go-qrcode/matrix"
+)
+
+func countTrue(m matrix.Matrix, x, y int, s matrix.State) int {
+	if s == matrix.StateTrue {
+		return 1
+	}
+	return 0
+}
+
+func TestParallelPenaltyMatchesSerialCount(t *testing.T) {
+	const size = 25
+	m := matrix.NewDense(size, size)
+	want := 0
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if (x*3+y)%4 == 0 {
+				_ = m.Set(x, y, matrix.StateTrue)
+				want++
+			}
+		}
+	}
+
+	got := ParallelPenalty(m, matrix.COLUMN, 4, countTrue)
+	if got != want {
+		t.Fatalf("ParallelPenalty = %d, want %d", got, want)
+	}
+}
+
+func TestParallelPenaltyFallsBackForUnsupportedBackend(t *testing.T) {
+	const size = 11
+	m := matrix.NewPacked(size, size)
+	want := 0
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if (x+y)%3 == 0 {
+				_ = m.Set(x, y, matrix.StateTrue)
+				want++
+			}
+		}
+	}
+
+	got := ParallelPenalty(m, matrix.COLUMN, 4, countTrue)
+	if got != want {
+		t.Fatalf("ParallelPenalty = %d, want %d", got, want)
+	}
+}
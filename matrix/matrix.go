@@ -49,9 +49,11 @@ func (s State) String() string {
 var (
 	// ErrorOutRangeOfW x out of range of Width
 	ErrorOutRangeOfW = errors.New("out of range of width")
-
 	// ErrorOutRangeOfH y out of range of Height
 	ErrorOutRangeOfH = errors.New("out of range of height")
+	// ErrorInvalidState is returned when a Set call is given a State
+	// value outside StateInit-StateFinder (0x0-0x5).
+	ErrorInvalidState = errors.New("invalid state")
 )
 
 // StateSliceMatched should be
@@ -69,14 +71,58 @@ func StateSliceMatched(ss1, ss2 []State) bool {
 	return true
 }
 
-// New generate a matrix with map[][]bool
-func New(width, height int) *Matrix {
+// IterateFunc ...
+type IterateFunc func(int, int, State)
+
+// Matrix is the common interface implemented by every matrix backend.
+//
+// It is modeled on gonum's mat.Matrix: Dims/At describe the shape and
+// read access, while Set/Iterate/Row/Col/Copy cover the mutation and
+// traversal patterns the QR encoder relies on. Programming against this
+// interface (rather than a concrete struct) lets callers swap in a
+// backend suited to their memory/performance tradeoffs - e.g. the dense
+// backend below, or a packed-bitset backend for large batch renders.
+type Matrix interface {
+	// Width returns the matrix width.
+	Width() int
+	// Height returns the matrix height.
+	Height() int
+	// Dims returns the matrix width and height, gonum-style.
+	Dims() (w, h int)
+
+	// At returns the state at (w, h), or StateInit if out of range.
+	At(w, h int) State
+	// Get returns the state at (w, h).
+	Get(w, h int) (State, error)
+	// Set sets the state at (w, h).
+	Set(w, h int, s State) error
+
+	// Iterate walks every cell in the given scan direction.
+	Iterate(dir ScanDirection, f IterateFunc)
+	// Row returns a copy of row cur (y dimension).
+	Row(cur int) []State
+	// Col returns a copy of column cur (x dimension).
+	Col(cur int) []State
+
+	// Copy returns an independent copy of the matrix, using the same backend.
+	Copy() Matrix
+}
+
+// New generates a dense matrix with map[][]bool.
+func New(width, height int) Matrix {
+	return NewDense(width, height)
+}
+
+// NewDense generates a Dense matrix, the original [][]State backed
+// implementation. It trades memory for simplicity: one State (2 bytes)
+// per cell.
+func NewDense(width, height int) *Dense {
 	mat := make([][]State, width)
 	for w := 0; w < width; w++ {
 		mat[w] = make([]State, height)
 	}
 
-	m := &Matrix{
+	m := &Dense{
 		mat:    mat,
 		width:  width,
 		height: height,
@@ -86,25 +132,42 @@ func New(width, height int) *Matrix {
 	return m
 }
 
-// Matrix is a matrix data type
+// Dense is a matrix data type backed by a plain [][]State.
 // width:3 height: 4 for [3][4]int
-type Matrix struct {
+type Dense struct {
 	mat    [][]State
 	width  int
 	height int
+
+	// nonInitRow[h] tracks, per row, which columns hold a non-StateInit
+	// value; nonInitCol[w] tracks the same per column. Together they
+	// let IterateState/RowStateDoer/ColStateDoer skip straight to the
+	// matching cells in either scan direction. See IterateState.
+	nonInitRow []stateBitmap
+	nonInitCol []stateBitmap
 }
 
 // do some init work
-func (m *Matrix) init() {
+func (m *Dense) init() {
 	for w := 0; w < m.width; w++ {
 		for h := 0; h < m.height; h++ {
 			m.mat[w][h] = StateInit
 		}
 	}
+
+	m.nonInitRow = make([]stateBitmap, m.height)
+	for h := 0; h < m.height; h++ {
+		m.nonInitRow[h] = newStateBitmap(m.width)
+	}
+
+	m.nonInitCol = make([]stateBitmap, m.width)
+	for w := 0; w < m.width; w++ {
+		m.nonInitCol[w] = newStateBitmap(m.height)
+	}
 }
 
 // Print to stdout
-func (m *Matrix) print() {
+func (m *Dense) print() {
 	m.Iterate(ROW, func(x, y int, s State) {
 		fmt.Printf("%2d ", s)
 		if (x + 1) == m.width {
@@ -113,39 +176,66 @@ func (m *Matrix) print() {
 	})
 }
 
-func (m *Matrix) Print() {
+func (m *Dense) Print() {
 	m.print()
 }
 
 // Copy matrix into a new Matrix
-func (m *Matrix) Copy() *Matrix {
+func (m *Dense) Copy() Matrix {
 	mat2 := make([][]State, m.width)
 	for w := 0; w < m.width; w++ {
 		mat2[w] = make([]State, m.height)
 		copy(mat2[w], m.mat[w])
 	}
 
-	m2 := &Matrix{
-		width:  m.width,
-		height: m.height,
-		mat:    mat2,
+	nonInitRow2 := make([]stateBitmap, m.height)
+	for h := 0; h < m.height; h++ {
+		nonInitRow2[h] = make(stateBitmap, len(m.nonInitRow[h]))
+		copy(nonInitRow2[h], m.nonInitRow[h])
+	}
+
+	nonInitCol2 := make([]stateBitmap, m.width)
+	for w := 0; w < m.width; w++ {
+		nonInitCol2[w] = make(stateBitmap, len(m.nonInitCol[w]))
+		copy(nonInitCol2[w], m.nonInitCol[w])
+	}
+
+	m2 := &Dense{
+		width:      m.width,
+		height:     m.height,
+		mat:        mat2,
+		nonInitRow: nonInitRow2,
+		nonInitCol: nonInitCol2,
 	}
 
 	return m2
 }
 
 // Width ... width
-func (m *Matrix) Width() int {
+func (m *Dense) Width() int {
 	return m.width
 }
 
 // Height ... height
-func (m *Matrix) Height() int {
+func (m *Dense) Height() int {
 	return m.height
 }
 
+// Dims returns the matrix width and height, gonum-style.
+func (m *Dense) Dims() (w, h int) {
+	return m.width, m.height
+}
+
+// At returns the state at (w, h), or StateInit if out of range.
+func (m *Dense) At(w, h int) State {
+	if w >= m.width || w < 0 || h >= m.height || h < 0 {
+		return StateInit
+	}
+	return m.mat[w][h]
+}
+
 // Set [w][h] as true
-func (m *Matrix) Set(w, h int, c State) error {
+func (m *Dense) Set(w, h int, c State) error {
 	if w >= m.width || w < 0 {
 		return ErrorOutRangeOfW
 	}
@@ -153,11 +243,18 @@ func (m *Matrix) Set(w, h int, c State) error {
 		return ErrorOutRangeOfH
 	}
 	m.mat[w][h] = c
+	if c == StateInit {
+		m.nonInitRow[h].clear(w)
+		m.nonInitCol[w].clear(h)
+	} else {
+		m.nonInitRow[h].set(w)
+		m.nonInitCol[w].set(h)
+	}
 	return nil
 }
 
 // Get state value from matrix with position {x, y}
-func (m *Matrix) Get(w, h int) (State, error) {
+func (m *Dense) Get(w, h int) (State, error) {
 	if w >= m.width || w < 0 {
 		return StateInit, ErrorOutRangeOfW
 	}
@@ -167,12 +264,9 @@ func (m *Matrix) Get(w, h int) (State, error) {
 	return m.mat[w][h], nil
 }
 
-// IterateFunc ...
-type IterateFunc func(int, int, State)
-
 // Iterate the Matrix with loop direction ROW major or COLUMN major.
 // COLUMN is recommended.
-func (m *Matrix) Iterate(dir ScanDirection, f IterateFunc) {
+func (m *Dense) Iterate(dir ScanDirection, f IterateFunc) {
 	// row direction first
 	if dir == ROW {
 		for h := 0; h < m.height; h++ {
@@ -194,21 +288,13 @@ func (m *Matrix) Iterate(dir ScanDirection, f IterateFunc) {
 	}
 }
 
-// XOR ...
-func XOR(s1, s2 State) State {
-	if s1 != s2 {
-		return StateTrue
-	}
-	return StateFalse
-}
-
 // Row return a row of matrix, cur should be y dimension.
-func (m *Matrix) Row(cur int) []State {
+func (m *Dense) Row(cur int) []State {
 	if cur >= m.height || cur < 0 {
 		return nil
 	}
 
-	col := make([]State, m.height)
+	col := make([]State, m.width)
 	for w := 0; w < m.width; w++ {
 		col[w] = m.mat[w][cur]
 	}
@@ -216,10 +302,20 @@ func (m *Matrix) Row(cur int) []State {
 }
 
 // Col return a slice of column, cur should be x dimension.
-func (m *Matrix) Col(cur int) []State {
+func (m *Dense) Col(cur int) []State {
 	if cur >= m.width || cur < 0 {
 		return nil
 	}
 
-	return m.mat[cur]
+	col := make([]State, m.height)
+	copy(col, m.mat[cur])
+	return col
+}
+
+// XOR ...
+func XOR(s1, s2 State) State {
+	if s1 != s2 {
+		return StateTrue
+	}
+	return StateFalse
 }
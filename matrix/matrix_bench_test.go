@@ -0,0 +1,39 @@
+package matrix
+
+import "testing"
+
+// maskEvaluate simulates the kind of full-matrix scan the QR mask
+// penalty rules perform: visit every cell and flip it based on a mask
+// pattern, the dominant access pattern during mask selection.
+func maskEvaluate(m Matrix) int {
+	score := 0
+	m.Iterate(COLUMN, func(x, y int, s State) {
+		if (x+y)%2 == 0 {
+			score++
+		}
+	})
+	return score
+}
+
+func benchmarkMaskEvaluate(b *testing.B, m Matrix) {
+	w, h := m.Dims()
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			_ = m.Set(x, y, StateTrue)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		maskEvaluate(m)
+	}
+}
+
+// 177x177 is the v40 QR code size, the largest and most expensive to scan.
+func BenchmarkMaskEvaluateDense_V40(b *testing.B) {
+	benchmarkMaskEvaluate(b, NewDense(177, 177))
+}
+
+func BenchmarkMaskEvaluatePacked_V40(b *testing.B) {
+	benchmarkMaskEvaluate(b, NewPacked(177, 177))
+}
@@ -0,0 +1,142 @@
+package matrix
+
+// Packed is a Matrix backend that stores each cell in 4 bits rather than
+// the 16 bits Dense spends on a State. State only ever takes values
+// 0x0-0x5, so two cells share a single byte. For a v40 QR code
+// (177x177) this cuts the matrix footprint roughly 4x versus Dense,
+// which matters when rendering many codes in a batch.
+type Packed struct {
+	bits   []byte
+	width  int
+	height int
+}
+
+// NewPacked generates a Packed matrix, a 4-bit-per-cell bitset backend.
+func NewPacked(width, height int) *Packed {
+	return &Packed{
+		bits:   make([]byte, (width*height+1)/2),
+		width:  width,
+		height: height,
+	}
+}
+
+// index returns the byte index and nibble shift for cell (w, h).
+func (m *Packed) index(w, h int) (idx int, shift uint) {
+	n := w*m.height + h
+	return n / 2, uint(n%2) * 4
+}
+
+// Width ... width
+func (m *Packed) Width() int {
+	return m.width
+}
+
+// Height ... height
+func (m *Packed) Height() int {
+	return m.height
+}
+
+// Dims returns the matrix width and height, gonum-style.
+func (m *Packed) Dims() (w, h int) {
+	return m.width, m.height
+}
+
+// At returns the state at (w, h), or StateInit if out of range.
+func (m *Packed) At(w, h int) State {
+	if w >= m.width || w < 0 || h >= m.height || h < 0 {
+		return StateInit
+	}
+	idx, shift := m.index(w, h)
+	return State((m.bits[idx] >> shift) & 0xF)
+}
+
+// Get state value from matrix with position {x, y}
+func (m *Packed) Get(w, h int) (State, error) {
+	if w >= m.width || w < 0 {
+		return StateInit, ErrorOutRangeOfW
+	}
+	if h >= m.height || h < 0 {
+		return StateInit, ErrorOutRangeOfH
+	}
+	idx, shift := m.index(w, h)
+	return State((m.bits[idx] >> shift) & 0xF), nil
+}
+
+// Set [w][h] as c. c must be one of the defined State values
+// (StateInit-StateFinder, 0x0-0x5); anything wider than the 4 bits a
+// cell stores would silently alias another state, so Set rejects it
+// with ErrorInvalidState instead of truncating.
+func (m *Packed) Set(w, h int, c State) error {
+	if w >= m.width || w < 0 {
+		return ErrorOutRangeOfW
+	}
+	if h >= m.height || h < 0 {
+		return ErrorOutRangeOfH
+	}
+	if c > StateFinder {
+		return ErrorInvalidState
+	}
+	idx, shift := m.index(w, h)
+	m.bits[idx] = (m.bits[idx] &^ (0xF << shift)) | (byte(c) << shift)
+	return nil
+}
+
+// Iterate the Matrix with loop direction ROW major or COLUMN major.
+// COLUMN is recommended.
+func (m *Packed) Iterate(dir ScanDirection, f IterateFunc) {
+	if dir == ROW {
+		for h := 0; h < m.height; h++ {
+			for w := 0; w < m.width; w++ {
+				f(w, h, m.At(w, h))
+			}
+		}
+		return
+	}
+
+	if dir == COLUMN {
+		for w := 0; w < m.width; w++ {
+			for h := 0; h < m.height; h++ {
+				f(w, h, m.At(w, h))
+			}
+		}
+		return
+	}
+}
+
+// Row return a row of matrix, cur should be y dimension.
+func (m *Packed) Row(cur int) []State {
+	if cur >= m.height || cur < 0 {
+		return nil
+	}
+
+	row := make([]State, m.width)
+	for w := 0; w < m.width; w++ {
+		row[w] = m.At(w, cur)
+	}
+	return row
+}
+
+// Col return a slice of column, cur should be x dimension.
+func (m *Packed) Col(cur int) []State {
+	if cur >= m.width || cur < 0 {
+		return nil
+	}
+
+	col := make([]State, m.height)
+	for h := 0; h < m.height; h++ {
+		col[h] = m.At(cur, h)
+	}
+	return col
+}
+
+// Copy matrix into a new Matrix
+func (m *Packed) Copy() Matrix {
+	bits2 := make([]byte, len(m.bits))
+	copy(bits2, m.bits)
+
+	return &Packed{
+		bits:   bits2,
+		width:  m.width,
+		height: m.height,
+	}
+}
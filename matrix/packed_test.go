@@ -0,0 +1,23 @@
+package matrix
+
+import "testing"
+
+func TestPackedSetRejectsInvalidState(t *testing.T) {
+	m := NewPacked(5, 5)
+	if err := m.Set(2, 2, State(0x10)); err != ErrorInvalidState {
+		t.Fatalf("Set with out-of-range state = %v, want ErrorInvalidState", err)
+	}
+	if got := m.At(2, 2); got != StateInit {
+		t.Fatalf("At after rejected Set = %v, want StateInit unchanged", got)
+	}
+}
+
+func TestPackedSetRoundTrip(t *testing.T) {
+	m := NewPacked(5, 5)
+	if err := m.Set(3, 4, StateFinder); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := m.At(3, 4); got != StateFinder {
+		t.Fatalf("At = %v, want StateFinder", got)
+	}
+}
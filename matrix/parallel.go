@@ -0,0 +1,86 @@
+package matrix
+
+import "sync"
+
+// ParallelIterateFunc is like IterateFunc, but also receives the index
+// of the stripe the cell was visited from. Callers that need to
+// accumulate a result (e.g. a penalty score) across stripes should key
+// a per-stripe slice by this index rather than sharing a single
+// variable, so concurrent stripes never contend on the same memory.
+type ParallelIterateFunc func(stripe, x, y int, s State)
+
+// IterateParallel partitions the matrix into stripes contiguous column
+// (dir == COLUMN) or row (dir == ROW) bands and visits each band in its
+// own goroutine, joining on a sync.WaitGroup before returning. f is
+// called concurrently from up to stripes goroutines and must be
+// goroutine-safe: it must not touch shared state outside of what it can
+// safely write from multiple goroutines (a per-stripe accumulator
+// indexed by the stripe argument is the intended pattern - see
+// mask.ParallelPenalty). Iterate is left untouched for callers that
+// want the simple serial scan.
+func (m *Dense) IterateParallel(dir ScanDirection, stripes int, f ParallelIterateFunc) {
+	if stripes < 1 {
+		stripes = 1
+	}
+
+	switch dir {
+	case COLUMN:
+		bands := stripeBounds(m.width, stripes)
+		var wg sync.WaitGroup
+		for s, b := range bands {
+			wg.Add(1)
+			go func(stripe, lo, hi int) {
+				defer wg.Done()
+				for w := lo; w < hi; w++ {
+					for h := 0; h < m.height; h++ {
+						f(stripe, w, h, m.mat[w][h])
+					}
+				}
+			}(s, b[0], b[1])
+		}
+		wg.Wait()
+
+	case ROW:
+		bands := stripeBounds(m.height, stripes)
+		var wg sync.WaitGroup
+		for s, b := range bands {
+			wg.Add(1)
+			go func(stripe, lo, hi int) {
+				defer wg.Done()
+				for h := lo; h < hi; h++ {
+					for w := 0; w < m.width; w++ {
+						f(stripe, w, h, m.mat[w][h])
+					}
+				}
+			}(s, b[0], b[1])
+		}
+		wg.Wait()
+	}
+}
+
+// stripeBounds splits [0, n) into up to stripes contiguous, roughly
+// equal bands, returning each band's [lo, hi) bounds. Bands beyond n
+// are omitted rather than left empty.
+func stripeBounds(n, stripes int) [][2]int {
+	if stripes > n {
+		stripes = n
+	}
+	if stripes < 1 {
+		return nil
+	}
+
+	base := n / stripes
+	rem := n % stripes
+
+	bands := make([][2]int, stripes)
+	lo := 0
+	for s := 0; s < stripes; s++ {
+		size := base
+		if s < rem {
+			size++
+		}
+		bands[s] = [2]int{lo, lo + size}
+		lo += size
+	}
+	return bands
+}
@@ -0,0 +1,58 @@
+package matrix
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestIterateParallelVisitsEveryCellOnce(t *testing.T) {
+	const w, h = 13, 9
+	m := NewDense(w, h)
+
+	var mu sync.Mutex
+	seen := make(map[[2]int]bool)
+
+	m.IterateParallel(COLUMN, 4, func(stripe, x, y int, s State) {
+		mu.Lock()
+		seen[[2]int{x, y}] = true
+		mu.Unlock()
+	})
+
+	if len(seen) != w*h {
+		t.Fatalf("visited %d cells, want %d", len(seen), w*h)
+	}
+}
+
+func TestIterateParallelStripesPartitionColumns(t *testing.T) {
+	const w, h = 10, 2
+	m := NewDense(w, h)
+
+	var mu sync.Mutex
+	stripeCols := map[int]map[int]bool{}
+
+	m.IterateParallel(COLUMN, 3, func(stripe, x, y int, s State) {
+		mu.Lock()
+		if stripeCols[stripe] == nil {
+			stripeCols[stripe] = map[int]bool{}
+		}
+		stripeCols[stripe][x] = true
+		mu.Unlock()
+	})
+
+	var allCols []int
+	for _, cols := range stripeCols {
+		for x := range cols {
+			allCols = append(allCols, x)
+		}
+	}
+	sort.Ints(allCols)
+	if len(allCols) != w {
+		t.Fatalf("stripes covered %d distinct columns, want %d", len(allCols), w)
+	}
+	for i, x := range allCols {
+		if x != i {
+			t.Fatalf("column %d missing or duplicated across stripes: %v", i, allCols)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package matrix
+
+// stateBitmap is a per-row bitset recording which columns of that row
+// currently hold a non-StateInit value. It is maintained incrementally
+// by Dense.Set so that IterateState/RowStateDoer/ColStateDoer can skip
+// straight to the columns worth visiting instead of touching every
+// cell, which matters once the mask penalty rules and finder-pattern
+// search only care about a handful of reserved states out of W*H cells.
+type stateBitmap []uint64
+
+func newStateBitmap(n int) stateBitmap {
+	return make(stateBitmap, (n+63)/64)
+}
+
+func (b stateBitmap) set(i int) {
+	b[i/64] |= 1 << uint(i%64)
+}
+
+func (b stateBitmap) clear(i int) {
+	b[i/64] &^= 1 << uint(i%64)
+}
+
+// each iterates the set bit positions in ascending order.
+func (b stateBitmap) each(f func(i int)) {
+	for word, bits := range b {
+		for bits != 0 {
+			i := word*64 + trailingZeros64(bits)
+			f(i)
+			bits &= bits - 1
+		}
+	}
+}
+
+func trailingZeros64(x uint64) int {
+	n := 0
+	for x&1 == 0 {
+		x >>= 1
+		n++
+	}
+	return n
+}
+
+// stateMask is a bitmask over State values (0x0-0x5 fit comfortably in a
+// byte) used to test membership against a variadic list of wanted
+// states without allocating.
+type stateMask uint8
+
+func newStateMask(states []State) stateMask {
+	var m stateMask
+	for _, s := range states {
+		m |= 1 << uint(s)
+	}
+	return m
+}
+
+func (m stateMask) has(s State) bool {
+	return m&(1<<uint(s)) != 0
+}
+
+func (m stateMask) hasInit() bool {
+	return m.has(StateInit)
+}
+
+// IterateState walks the matrix like Iterate, but only calls f for
+// cells whose state is one of states. Passing no states visits nothing;
+// passing StateInit falls back to a full scan, since non-init cells are
+// the only ones tracked in the per-row/per-column bitmaps. Both scan
+// directions are O(matches): ROW walks nonInitRow row by row, COLUMN
+// walks nonInitCol column by column.
+func (m *Dense) IterateState(dir ScanDirection, f IterateFunc, states ...State) {
+	if len(states) == 0 {
+		return
+	}
+	mask := newStateMask(states)
+
+	if mask.hasInit() {
+		m.Iterate(dir, func(x, y int, s State) {
+			if mask.has(s) {
+				f(x, y, s)
+			}
+		})
+		return
+	}
+
+	if dir == ROW {
+		for h := 0; h < m.height; h++ {
+			m.nonInitRow[h].each(func(w int) {
+				if s := m.mat[w][h]; mask.has(s) {
+					f(w, h, s)
+				}
+			})
+		}
+		return
+	}
+
+	if dir == COLUMN {
+		for w := 0; w < m.width; w++ {
+			m.nonInitCol[w].each(func(h int) {
+				if s := m.mat[w][h]; mask.has(s) {
+					f(w, h, s)
+				}
+			})
+		}
+		return
+	}
+}
+
+// RowStateDoer calls f for every cell in row cur whose state is one of
+// states, skipping columns known to be StateInit via the row's bitmap.
+func (m *Dense) RowStateDoer(cur int, f IterateFunc, states ...State) {
+	if cur >= m.height || cur < 0 || len(states) == 0 {
+		return
+	}
+	mask := newStateMask(states)
+
+	if mask.hasInit() {
+		for w := 0; w < m.width; w++ {
+			if s := m.mat[w][cur]; mask.has(s) {
+				f(w, cur, s)
+			}
+		}
+		return
+	}
+
+	m.nonInitRow[cur].each(func(w int) {
+		if s := m.mat[w][cur]; mask.has(s) {
+			f(w, cur, s)
+		}
+	})
+}
+
+// ColStateDoer calls f for every cell in column cur whose state is one
+// of states, skipping rows known to be StateInit via the column's
+// bitmap.
+func (m *Dense) ColStateDoer(cur int, f IterateFunc, states ...State) {
+	if cur >= m.width || cur < 0 || len(states) == 0 {
+		return
+	}
+	mask := newStateMask(states)
+
+	if mask.hasInit() {
+		for h := 0; h < m.height; h++ {
+			if s := m.mat[cur][h]; mask.has(s) {
+				f(cur, h, s)
+			}
+		}
+		return
+	}
+
+	m.nonInitCol[cur].each(func(h int) {
+		if s := m.mat[cur][h]; mask.has(s) {
+			f(cur, h, s)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package matrix
+
+import "testing"
+
+func TestIterateState(t *testing.T) {
+	m := NewDense(5, 5)
+	_ = m.Set(1, 1, StateFinder)
+	_ = m.Set(3, 3, StateFinder)
+	_ = m.Set(2, 2, StateTrue)
+
+	var got [][2]int
+	m.IterateState(COLUMN, func(x, y int, s State) {
+		got = append(got, [2]int{x, y})
+	}, StateFinder)
+
+	want := [][2]int{{1, 1}, {3, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestIterateStateColumnMultipleMatchesPerColumn(t *testing.T) {
+	m := NewDense(4, 6)
+	_ = m.Set(2, 0, StateFinder)
+	_ = m.Set(2, 4, StateFinder)
+	_ = m.Set(2, 5, StateFinder)
+	_ = m.Set(3, 1, StateFinder)
+
+	var got [][2]int
+	m.IterateState(COLUMN, func(x, y int, s State) {
+		got = append(got, [2]int{x, y})
+	}, StateFinder)
+
+	want := [][2]int{{2, 0}, {2, 4}, {2, 5}, {3, 1}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRowStateDoer(t *testing.T) {
+	m := NewDense(5, 5)
+	_ = m.Set(0, 2, StateFormat)
+	_ = m.Set(4, 2, StateFormat)
+	_ = m.Set(4, 3, StateFormat)
+
+	count := 0
+	m.RowStateDoer(2, func(x, y int, s State) {
+		count++
+	}, StateFormat)
+
+	if count != 2 {
+		t.Fatalf("got %d matches, want 2", count)
+	}
+}
+
+func TestColStateDoer(t *testing.T) {
+	m := NewDense(5, 5)
+	_ = m.Set(3, 0, StateVersion)
+	_ = m.Set(3, 4, StateVersion)
+	_ = m.Set(1, 4, StateVersion)
+
+	count := 0
+	m.ColStateDoer(3, func(x, y int, s State) {
+		count++
+	}, StateVersion)
+
+	if count != 2 {
+		t.Fatalf("got %d matches, want 2", count)
+	}
+}
@@ -0,0 +1,189 @@
+package matrix
+
+// View and T are free functions rather than methods on a concrete type
+// because, since the Matrix interface introduced alongside the
+// Dense/Packed backends, a view needs to wrap whatever backend it's
+// given - including another view, or a transpose - not just Dense.
+
+// View returns a window onto m spanning [x, x+w) x [y, y+h), sharing
+// storage with m: writes through the view are visible in m and vice
+// versa. The view reports its own Width/Height and returns
+// ErrorOutRangeOfW/ErrorOutRangeOfH at the *window's* boundaries, not
+// m's, so code written against a view never needs to know it isn't the
+// whole matrix. This lets QR-encoding routines that place finder
+// patterns, alignment patterns, and timing lines write into a local
+// (0,0)-based coordinate system without allocating a temporary matrix.
+func View(m Matrix, x, y, w, h int) (Matrix, error) {
+	pw, ph := m.Dims()
+	if w < 0 || x < 0 || x+w > pw {
+		return nil, ErrorOutRangeOfW
+	}
+	if h < 0 || y < 0 || y+h > ph {
+		return nil, ErrorOutRangeOfH
+	}
+
+	return &viewMatrix{parent: m, x0: x, y0: y, w: w, h: h}, nil
+}
+
+type viewMatrix struct {
+	parent Matrix
+	x0, y0 int
+	w, h   int
+}
+
+func (v *viewMatrix) Width() int  { return v.w }
+func (v *viewMatrix) Height() int { return v.h }
+func (v *viewMatrix) Dims() (int, int) {
+	return v.w, v.h
+}
+
+func (v *viewMatrix) At(x, y int) State {
+	if x < 0 || x >= v.w || y < 0 || y >= v.h {
+		return StateInit
+	}
+	return v.parent.At(v.x0+x, v.y0+y)
+}
+
+func (v *viewMatrix) Get(x, y int) (State, error) {
+	if x < 0 || x >= v.w {
+		return StateInit, ErrorOutRangeOfW
+	}
+	if y < 0 || y >= v.h {
+		return StateInit, ErrorOutRangeOfH
+	}
+	return v.parent.Get(v.x0+x, v.y0+y)
+}
+
+func (v *viewMatrix) Set(x, y int, s State) error {
+	if x < 0 || x >= v.w {
+		return ErrorOutRangeOfW
+	}
+	if y < 0 || y >= v.h {
+		return ErrorOutRangeOfH
+	}
+	return v.parent.Set(v.x0+x, v.y0+y, s)
+}
+
+func (v *viewMatrix) Iterate(dir ScanDirection, f IterateFunc) {
+	if dir == ROW {
+		for y := 0; y < v.h; y++ {
+			for x := 0; x < v.w; x++ {
+				f(x, y, v.At(x, y))
+			}
+		}
+		return
+	}
+
+	if dir == COLUMN {
+		for x := 0; x < v.w; x++ {
+			for y := 0; y < v.h; y++ {
+				f(x, y, v.At(x, y))
+			}
+		}
+		return
+	}
+}
+
+func (v *viewMatrix) Row(cur int) []State {
+	if cur >= v.h || cur < 0 {
+		return nil
+	}
+	row := make([]State, v.w)
+	for x := 0; x < v.w; x++ {
+		row[x] = v.At(x, cur)
+	}
+	return row
+}
+
+func (v *viewMatrix) Col(cur int) []State {
+	if cur >= v.w || cur < 0 {
+		return nil
+	}
+	col := make([]State, v.h)
+	for y := 0; y < v.h; y++ {
+		col[y] = v.At(cur, y)
+	}
+	return col
+}
+
+// Copy materializes an independent Dense snapshot of the view; unlike
+// the view itself, the result shares no storage with the parent.
+func (v *viewMatrix) Copy() Matrix {
+	out := NewDense(v.w, v.h)
+	v.Iterate(COLUMN, func(x, y int, s State) {
+		_ = out.Set(x, y, s)
+	})
+	return out
+}
+
+// T returns a transposed view of m that swaps the W/H axes without
+// copying: T(m).At(x, y) == m.At(y, x). Transposing a transpose hands
+// back the original matrix rather than double-wrapping.
+func T(m Matrix) Matrix {
+	if t, ok := m.(*transposeMatrix); ok {
+		return t.src
+	}
+	return &transposeMatrix{src: m}
+}
+
+type transposeMatrix struct {
+	src Matrix
+}
+
+func (t *transposeMatrix) Width() int  { h, _ := t.dims(); return h }
+func (t *transposeMatrix) Height() int { _, w := t.dims(); return w }
+
+func (t *transposeMatrix) dims() (w, h int) {
+	sw, sh := t.src.Dims()
+	return sh, sw
+}
+
+func (t *transposeMatrix) Dims() (w, h int) {
+	return t.dims()
+}
+
+func (t *transposeMatrix) At(x, y int) State {
+	return t.src.At(y, x)
+}
+
+func (t *transposeMatrix) Get(x, y int) (State, error) {
+	return t.src.Get(y, x)
+}
+
+func (t *transposeMatrix) Set(x, y int, s State) error {
+	return t.src.Set(y, x, s)
+}
+
+func (t *transposeMatrix) Iterate(dir ScanDirection, f IterateFunc) {
+	w, h := t.dims()
+	if dir == ROW {
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				f(x, y, t.At(x, y))
+			}
+		}
+		return
+	}
+
+	if dir == COLUMN {
+		for x := 0; x < w; x++ {
+			for y := 0; y < h; y++ {
+				f(x, y, t.At(x, y))
+			}
+		}
+		return
+	}
+}
+
+func (t *transposeMatrix) Row(cur int) []State {
+	return t.src.Col(cur)
+}
+
+func (t *transposeMatrix) Col(cur int) []State {
+	return t.src.Row(cur)
+}
+
+func (t *transposeMatrix) Copy() Matrix {
+	out := t.src.Copy()
+	return T(out)
+}
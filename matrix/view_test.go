@@ -0,0 +1,100 @@
+package matrix
+
+import "testing"
+
+func TestViewSharesStorageWithParent(t *testing.T) {
+	parent := NewDense(10, 10)
+	v, err := View(parent, 2, 3, 4, 4)
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	if err := v.Set(0, 0, StateFinder); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, _ := parent.Get(2, 3); got != StateFinder {
+		t.Fatalf("write through view not visible in parent: got %v", got)
+	}
+
+	if err := parent.Set(3, 4, StateVersion); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := v.At(1, 1); got != StateVersion {
+		t.Fatalf("write to parent not visible in view: got %v", got)
+	}
+}
+
+func TestViewBoundsAreWindowRelative(t *testing.T) {
+	parent := NewDense(10, 10)
+	v, err := View(parent, 2, 2, 3, 3)
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	if err := v.Set(3, 0, StateTrue); err != ErrorOutRangeOfW {
+		t.Fatalf("Set(3,0) = %v, want ErrorOutRangeOfW", err)
+	}
+	if err := v.Set(0, 3, StateTrue); err != ErrorOutRangeOfH {
+		t.Fatalf("Set(0,3) = %v, want ErrorOutRangeOfH", err)
+	}
+	if err := v.Set(2, 2, StateTrue); err != nil {
+		t.Fatalf("Set(2,2) = %v, want nil", err)
+	}
+}
+
+func TestViewOutOfParentRange(t *testing.T) {
+	parent := NewDense(10, 10)
+	if _, err := View(parent, 8, 0, 5, 1); err != ErrorOutRangeOfW {
+		t.Fatalf("View past parent width = %v, want ErrorOutRangeOfW", err)
+	}
+	if _, err := View(parent, 0, 8, 1, 5); err != ErrorOutRangeOfH {
+		t.Fatalf("View past parent height = %v, want ErrorOutRangeOfH", err)
+	}
+}
+
+func TestViewEqual(t *testing.T) {
+	parent := NewDense(6, 6)
+	_ = parent.Set(2, 2, StateFinder)
+	_ = parent.Set(3, 2, StateFinder)
+
+	v, err := View(parent, 2, 2, 2, 1)
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	expected := NewDense(2, 1)
+	_ = expected.Set(0, 0, StateFinder)
+	_ = expected.Set(1, 0, StateFinder)
+
+	if !Equal(v, expected) {
+		t.Fatalf("view does not equal expected region")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	m := NewDense(3, 2)
+	_ = m.Set(0, 1, StateFinder)
+
+	tr := T(m)
+	w, h := tr.Dims()
+	if w != 2 || h != 3 {
+		t.Fatalf("T().Dims() = (%d, %d), want (2, 3)", w, h)
+	}
+	if got := tr.At(1, 0); got != StateFinder {
+		t.Fatalf("T().At(1, 0) = %v, want StateFinder", got)
+	}
+
+	if err := tr.Set(1, 2, StateVersion); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := m.At(2, 1); got != StateVersion {
+		t.Fatalf("write through T() not visible in source: got %v", got)
+	}
+}
+
+func TestTransposeTransposeReturnsOriginal(t *testing.T) {
+	m := NewDense(3, 2)
+	if T(T(m)) != Matrix(m) {
+		t.Fatal("T(T(m)) should return the original matrix, not a double-wrapped view")
+	}
+}